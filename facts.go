@@ -0,0 +1,133 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"fact-generator/internal/api"
+	"fact-generator/internal/storage"
+)
+
+const defaultFactsPageSize = 20
+
+func toAPIFact(f storage.Fact) api.Fact {
+	return api.Fact{
+		Id:        f.ID,
+		Topic:     f.Topic,
+		Model:     f.Model,
+		Content:   f.Content,
+		Rating:    f.Rating,
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+// ListFacts — GET /api/facts
+func (apiServer) ListFacts(w http.ResponseWriter, r *http.Request, params api.ListFactsParams) {
+	topic := ""
+	if params.Topic != nil {
+		topic = *params.Topic
+	}
+	limit := defaultFactsPageSize
+	if params.Limit != nil && *params.Limit > 0 {
+		limit = *params.Limit
+	}
+	offset := 0
+	if params.Offset != nil && *params.Offset >= 0 {
+		offset = *params.Offset
+	}
+
+	facts, err := store.ListFacts(r.Context(), topic, limit, offset)
+	if err != nil {
+		http.Error(w, "Ошибка выборки фактов: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]api.Fact, 0, len(facts))
+	for _, f := range facts {
+		resp = append(resp, toAPIFact(f))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetFact — GET /api/facts/{id}
+func (apiServer) GetFact(w http.ResponseWriter, r *http.Request, id int64) {
+	fact, err := store.GetFact(r.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Факт не найден", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Ошибка выборки факта: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toAPIFact(*fact))
+}
+
+// RateFact — POST /api/facts/{id}/rate
+func (apiServer) RateFact(w http.ResponseWriter, r *http.Request, id int64) {
+	var body api.RateRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Ошибка чтения запроса: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := store.RateFact(r.Context(), id, body.Rating); errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Факт не найден", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, "Ошибка сохранения оценки: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteFact — DELETE /api/facts/{id}
+func (apiServer) DeleteFact(w http.ResponseWriter, r *http.Request, id int64) {
+	if err := store.DeleteFact(r.Context(), id); errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Факт не найден", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, "Ошибка удаления факта: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListTopics — GET /api/topics
+func (apiServer) ListTopics(w http.ResponseWriter, r *http.Request) {
+	topics, err := store.TopTopics(r.Context(), defaultFactsPageSize)
+	if err != nil {
+		http.Error(w, "Ошибка выборки тем: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]api.TopicCount, 0, len(topics))
+	for _, t := range topics {
+		resp = append(resp, api.TopicCount{Topic: t.Topic, Count: t.Count})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ListPrompts — GET /api/prompts
+func (apiServer) ListPrompts(w http.ResponseWriter, r *http.Request) {
+	keys := promptManager.Keys()
+
+	resp := make([]api.PromptOption, 0, len(keys))
+	for _, k := range keys {
+		resp = append(resp, api.PromptOption{Locale: k.Locale, Style: k.Style})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
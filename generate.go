@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"fact-generator/internal/api"
+	"fact-generator/internal/llm"
+	"fact-generator/internal/prompts"
+)
+
+// apiServer реализует api.ServerInterface, сгенерированный из openapi.yaml.
+type apiServer struct{}
+
+// streamHTTPClient используется только для потокового запроса в streamFact.
+// internal/httpclient.Client здесь не подходит: он читает тело ответа
+// целиком и применяет таймаут на весь запрос, а SSE-соединение держится
+// открытым, пока идёт генерация, — его обрыв по таймауту отменяет стрим
+// раньше времени. Здесь достаточно отмены через r.Context().
+var streamHTTPClient = &http.Client{}
+
+// GenerateFact оборачивает generateFactPost ограничением частоты запросов,
+// как и раньше делал rateLimitMiddleware вокруг /generate-fact.
+func (apiServer) GenerateFact(w http.ResponseWriter, r *http.Request) {
+	rateLimitMiddleware(http.HandlerFunc(generateFactPost)).ServeHTTP(w, r)
+}
+
+func generateFactPost(w http.ResponseWriter, r *http.Request) {
+	var req api.GenerateFactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Ошибка чтения запроса: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	topic := ""
+	if req.Topic != nil {
+		topic = *req.Topic
+	}
+
+	model := "deepseek-chat"
+	if req.Model != nil && *req.Model != "" {
+		model = *req.Model
+	}
+
+	locale, style, length := "", "", ""
+	if req.Locale != nil {
+		locale = *req.Locale
+	}
+	if req.Style != nil {
+		style = *req.Style
+	}
+	if req.Length != nil {
+		length = *req.Length
+	}
+
+	prompt, err := promptManager.Render(promptManager.Resolve(locale, style), prompts.Data{Topic: topic, Length: length})
+	if err != nil {
+		http.Error(w, "Ошибка подготовки промпта: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	messages := []llm.Message{{Role: "user", Content: prompt}}
+
+	result, err := registry.Generate(r.Context(), model, messages)
+	if err != nil {
+		log.Printf("LLM error: %s", err)
+		http.Error(w, "Не удалось получить факт: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	id, err := store.InsertFact(r.Context(), topic, model, result.Content)
+	if err != nil {
+		log.Printf("Ошибка сохранения факта: %s", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.GenerateFactResponse{
+		Fact:       result.Content,
+		Id:         id,
+		Model:      model,
+		TokensUsed: result.TokensUsed,
+	})
+}
+
+// streamFactHandler обслуживает GET-вариант /generate-fact для EventSource,
+// который в OpenAPI-спеке не описан (SSE не укладывается в request/response
+// схему) и потому регистрируется отдельно от api.ServerInterface.
+func streamFactHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	streamFact(w, r, q.Get("topic"), q.Get("locale"), q.Get("style"), q.Get("length"))
+}
+
+// streamFact отправляет запрос к OpenRouter со "stream": true и пробрасывает
+// дельты токенов клиенту по мере их поступления через Server-Sent Events.
+// Ключ берётся через реестр провайдеров, чтобы учитывать ротацию ключей
+// из OPENROUTER_API_KEY (список через "|"), как и обычная генерация.
+func streamFact(w http.ResponseWriter, r *http.Request, topic, locale, style, length string) {
+	provider, model, ok := registry.Resolve("deepseek-chat")
+	if !ok {
+		http.Error(w, "LLM провайдер не найден", http.StatusInternalServerError)
+		return
+	}
+	key := provider.NextKey()
+	if provider.KeyCount() > 0 && key == "" {
+		http.Error(w, "API ключ не найден", http.StatusInternalServerError)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming не поддерживается", http.StatusInternalServerError)
+		return
+	}
+
+	prompt, err := promptManager.Render(promptManager.Resolve(locale, style), prompts.Data{Topic: topic, Length: length})
+	if err != nil {
+		http.Error(w, "Ошибка подготовки промпта: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	requestBody := map[string]interface{}{
+		"model": model,
+		"messages": []map[string]string{
+			{
+				"role":    "user",
+				"content": prompt,
+			},
+		},
+		"stream": true,
+	}
+
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		http.Error(w, "Ошибка при подготовке запроса: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), "POST", provider.Endpoint(), bytes.NewReader(jsonBody))
+	if err != nil {
+		http.Error(w, "Ошибка при создании запроса к API: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if auth := provider.AuthHeader(key); auth != "" {
+		req.Header.Add("Authorization", auth)
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := streamHTTPClient.Do(req)
+	if err != nil {
+		http.Error(w, "Ошибка при отправке запроса к API: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		log.Printf("API response body: %s", string(body))
+		http.Error(w, "Ошибка от API", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	// Разбираем SSE-кадры вида "data: {...}", которые отдаёт OpenRouter
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		data := strings.TrimPrefix(line, "data: ")
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk struct {
+			Choices []struct {
+				Delta struct {
+					Content string `json:"content"`
+				} `json:"delta"`
+			} `json:"choices"`
+		}
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			continue
+		}
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+
+		content := chunk.Choices[0].Delta.Content
+		if content == "" {
+			continue
+		}
+
+		for _, part := range strings.Split(content, "\n") {
+			fmt.Fprintf(w, "data: %s\n", part)
+		}
+		fmt.Fprint(w, "\n")
+		flusher.Flush()
+	}
+
+	fmt.Fprint(w, "event: done\ndata: \n\n")
+	flusher.Flush()
+}
@@ -0,0 +1,199 @@
+// Package httpclient — тонкая обёртка над http.Client с таймаутами,
+// повторами при временных ошибках и типизированной декодировкой JSON-ответов.
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultTimeout    = 30 * time.Second
+	defaultMaxRetries = 3
+)
+
+// Client — HTTP-клиент с настроенным таймаутом и политикой повторов.
+type Client struct {
+	http       *http.Client
+	maxRetries int
+}
+
+// New создаёт клиент с заданным таймаутом на запрос. timeout <= 0 означает
+// значение по умолчанию (30s).
+func New(timeout time.Duration) *Client {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Client{
+		http:       &http.Client{Timeout: timeout},
+		maxRetries: defaultMaxRetries,
+	}
+}
+
+// StatusError — ответ с кодом ошибки (4xx/5xx), сохранённый вместе с телом,
+// чтобы вызывающий код мог показать сообщение пользователю.
+type StatusError struct {
+	StatusCode int
+	Message    string
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("httpclient: статус %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("httpclient: статус %d", e.StatusCode)
+}
+
+// errorBody — распространённая форма тела ошибки у OpenAI-совместимых API.
+type errorBody struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Get выполняет GET-запрос и возвращает сырой *http.Response; вызывающий
+// код обязан закрыть resp.Body.
+func (c *Client) Get(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, url, headers, nil)
+}
+
+// PostJSON сериализует body в JSON и отправляет POST-запрос, возвращая
+// сырой *http.Response; вызывающий код обязан закрыть resp.Body.
+func (c *Client) PostJSON(ctx context.Context, url string, headers map[string]string, body interface{}) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, url, headers, body)
+}
+
+// Do[T] выполняет запрос и декодирует JSON-тело успешного ответа в T.
+// При статусе >= 400 возвращает *StatusError с сообщением, извлечённым
+// из тела ответа, если оно в распознаваемом формате.
+func Do[T any](ctx context.Context, c *Client, method, url string, headers map[string]string, reqBody interface{}) (T, error) {
+	var zero T
+
+	resp, err := c.do(ctx, method, url, headers, reqBody)
+	if err != nil {
+		return zero, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return zero, fmt.Errorf("httpclient: чтение ответа: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		var eb errorBody
+		_ = json.Unmarshal(data, &eb)
+		return zero, &StatusError{StatusCode: resp.StatusCode, Message: eb.Error.Message, Body: string(data)}
+	}
+
+	var result T
+	if err := json.Unmarshal(data, &result); err != nil {
+		return zero, fmt.Errorf("httpclient: разбор ответа: %w", err)
+	}
+	return result, nil
+}
+
+// do выполняет запрос, повторяя его при сетевых ошибках и при ответах
+// 429/502/503/504, с экспоненциальной задержкой (учитывая Retry-After).
+func (c *Client) do(ctx context.Context, method, url string, headers map[string]string, body interface{}) (*http.Response, error) {
+	var jsonBody []byte
+	if body != nil {
+		var err error
+		jsonBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("httpclient: подготовка запроса: %w", err)
+		}
+	}
+
+	var lastErr error
+	var retryAfter time.Duration
+
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := retryAfter
+			if delay <= 0 {
+				delay = backoffDelay(attempt)
+			}
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		var reader io.Reader
+		if jsonBody != nil {
+			reader = bytes.NewReader(jsonBody)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, url, reader)
+		if err != nil {
+			return nil, fmt.Errorf("httpclient: создание запроса: %w", err)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		if jsonBody != nil && req.Header.Get("Content-Type") == "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.http.Do(req)
+		if err != nil {
+			lastErr = err
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			retryAfter = 0
+			continue
+		}
+
+		if !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		retryAfter = retryAfterDelay(resp)
+		lastErr = fmt.Errorf("%s вернул статус %d", url, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	return nil, fmt.Errorf("httpclient: исчерпаны попытки: %w", lastErr)
+}
+
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfterDelay разбирает заголовок Retry-After (в секундах или как HTTP-дату).
+func retryAfterDelay(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// backoffDelay считает экспоненциальную задержку перед повтором: 200ms, 400ms, 800ms...
+func backoffDelay(attempt int) time.Duration {
+	return time.Duration(200*math.Pow(2, float64(attempt-1))) * time.Millisecond
+}
@@ -0,0 +1,56 @@
+package llm
+
+import (
+	"strings"
+	"sync"
+)
+
+// KeyRotator раздаёт ключи API по кругу (round-robin), безопасно для
+// конкурентного использования.
+type KeyRotator struct {
+	mu   sync.Mutex
+	keys []string
+	next int
+}
+
+// NewKeyRotator создаёт ротатор для заданного списка ключей. Список может
+// быть пустым — тогда Next всегда возвращает "".
+func NewKeyRotator(keys []string) *KeyRotator {
+	return &KeyRotator{keys: keys}
+}
+
+// Next возвращает следующий ключ по кругу.
+func (r *KeyRotator) Next() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.keys) == 0 {
+		return ""
+	}
+
+	key := r.keys[r.next%len(r.keys)]
+	r.next++
+	return key
+}
+
+// Len возвращает число ключей в ротаторе.
+func (r *KeyRotator) Len() int {
+	return len(r.keys)
+}
+
+// ParseKeys разбирает список ключей из переменной окружения вида
+// "key1|key2|key3", отбрасывая пустые элементы и пробелы по краям.
+func ParseKeys(env string) []string {
+	if env == "" {
+		return nil
+	}
+
+	var keys []string
+	for _, part := range strings.Split(env, "|") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			keys = append(keys, part)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,129 @@
+// Package llm предоставляет единый интерфейс для обращения к разным
+// LLM-бэкендам (OpenRouter, OpenAI, DeepSeek, локальный Ollama и т.д.)
+// с ротацией ключей и автоматическим переключением между провайдерами
+// при ошибках.
+package llm
+
+import (
+	"context"
+	"fmt"
+)
+
+// Message — одно сообщение в истории чата, в формате OpenAI-совместимых API.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Result — ответ провайдера вместе с числом потраченных токенов.
+type Result struct {
+	Content    string
+	TokensUsed int
+}
+
+// Provider описывает один LLM-бэкенд.
+type Provider interface {
+	// Name возвращает короткий идентификатор провайдера для логов и ошибок.
+	Name() string
+	// Endpoint возвращает URL эндпоинта chat completions.
+	Endpoint() string
+	// ResolveModel превращает логическое имя модели в идентификатор,
+	// специфичный для провайдера. ok == false, если провайдер не поддерживает
+	// запрошенную модель.
+	ResolveModel(logical string) (model string, ok bool)
+	// NextKey возвращает следующий ключ API по кругу, либо "", если ключи не заданы.
+	NextKey() string
+	// KeyCount возвращает число настроенных ключей.
+	KeyCount() int
+	// AuthHeader собирает значение заголовка Authorization для данного ключа.
+	AuthHeader(key string) string
+}
+
+// APIError — ошибка, полученная от провайдера, с сохранением HTTP-статуса,
+// чтобы вызывающий код мог решить, стоит ли повторять запрос.
+type APIError struct {
+	Provider   string
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %d: %s", e.Provider, e.StatusCode, e.Message)
+}
+
+// retryable сообщает, имеет ли смысл пробовать следующий ключ/провайдера
+// после такой ошибки: 401 (ключ невалиден), 429 (исчерпана квота) и 5xx
+// (временная проблема на стороне провайдера).
+func retryable(err error) bool {
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		// Сетевые ошибки и таймауты тоже стоит повторить на другом ключе/провайдере.
+		return true
+	}
+	switch apiErr.StatusCode {
+	case 401, 429:
+		return true
+	default:
+		return apiErr.StatusCode >= 500
+	}
+}
+
+// Registry хранит провайдеров в порядке предпочтения и перебирает их ключи
+// и сами провайдеры при ошибках.
+type Registry struct {
+	providers []Provider
+}
+
+// NewRegistry собирает реестр из уже сконфигурированных провайдеров.
+func NewRegistry(providers ...Provider) *Registry {
+	return &Registry{providers: providers}
+}
+
+// Resolve возвращает первого провайдера, поддерживающего logicalModel,
+// вместе с её идентификатором, специфичным для этого провайдера. Нужен
+// вызывающему коду, которому требуется прямой доступ к провайдеру — например,
+// для потоковой генерации через SSE, не укладывающейся в Generate.
+func (r *Registry) Resolve(logicalModel string) (p Provider, model string, ok bool) {
+	for _, p := range r.providers {
+		if model, ok := p.ResolveModel(logicalModel); ok {
+			return p, model, true
+		}
+	}
+	return nil, "", false
+}
+
+// Generate запрашивает факт у первого провайдера, поддерживающего logicalModel,
+// перебирая его ключи по кругу, а при исчерпании ключей — следующего провайдера.
+func (r *Registry) Generate(ctx context.Context, logicalModel string, messages []Message) (Result, error) {
+	var lastErr error
+	tried := false
+
+	for _, p := range r.providers {
+		model, ok := p.ResolveModel(logicalModel)
+		if !ok {
+			continue
+		}
+
+		attempts := p.KeyCount()
+		if attempts == 0 {
+			attempts = 1 // провайдеру вроде локальной Ollama ключ не нужен
+		}
+
+		for i := 0; i < attempts; i++ {
+			tried = true
+			result, err := callProvider(ctx, p, model, p.NextKey(), messages)
+			if err == nil {
+				return result, nil
+			}
+			lastErr = err
+			if !retryable(err) {
+				break
+			}
+		}
+	}
+
+	if !tried {
+		return Result{}, fmt.Errorf("нет провайдера, поддерживающего модель %q", logicalModel)
+	}
+	return Result{}, fmt.Errorf("все провайдеры недоступны: %w", lastErr)
+}
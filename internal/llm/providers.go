@@ -0,0 +1,188 @@
+package llm
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"fact-generator/internal/httpclient"
+)
+
+// client — общий HTTP-клиент для всех провайдеров, с таймаутом и повторами
+// при временных ошибках.
+var client = httpclient.New(30 * time.Second)
+
+// chatCompletionResponse — типизированный ответ chat completions в формате,
+// общем для OpenRouter, OpenAI, DeepSeek и Ollama (OpenAI-совместимый API).
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		TotalTokens int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// callProvider отправляет запрос chat completions конкретному провайдеру и
+// достаёт текст ответа вместе с числом потраченных токенов.
+func callProvider(ctx context.Context, p Provider, model, key string, messages []Message) (Result, error) {
+	requestBody := map[string]interface{}{
+		"model":    model,
+		"messages": messages,
+	}
+
+	headers := map[string]string{}
+	if auth := p.AuthHeader(key); auth != "" {
+		headers["Authorization"] = auth
+	}
+
+	resp, err := httpclient.Do[chatCompletionResponse](ctx, client, http.MethodPost, p.Endpoint(), headers, requestBody)
+	if err != nil {
+		if statusErr, ok := err.(*httpclient.StatusError); ok {
+			return Result{}, &APIError{Provider: p.Name(), StatusCode: statusErr.StatusCode, Message: statusErr.Message}
+		}
+		return Result{}, err
+	}
+
+	if len(resp.Choices) == 0 {
+		return Result{}, &APIError{Provider: p.Name(), StatusCode: http.StatusOK, Message: "неверный формат ответа"}
+	}
+
+	return Result{Content: resp.Choices[0].Message.Content, TokensUsed: resp.Usage.TotalTokens}, nil
+}
+
+// openRouterProvider обращается к OpenRouter, который сам проксирует
+// множество моделей под единым API.
+type openRouterProvider struct {
+	keys   *KeyRotator
+	models map[string]string
+}
+
+// NewOpenRouter создаёт провайдера OpenRouter, читая ключи из
+// OPENROUTER_API_KEY (список через "|").
+func NewOpenRouter() Provider {
+	return &openRouterProvider{
+		keys: NewKeyRotator(ParseKeys(os.Getenv("OPENROUTER_API_KEY"))),
+		models: map[string]string{
+			"deepseek-chat": "deepseek/deepseek-chat",
+			"gpt-4o-mini":   "openai/gpt-4o-mini",
+		},
+	}
+}
+
+func (p *openRouterProvider) Name() string     { return "openrouter" }
+func (p *openRouterProvider) Endpoint() string { return "https://openrouter.ai/api/v1/chat/completions" }
+func (p *openRouterProvider) ResolveModel(logical string) (string, bool) {
+	model, ok := p.models[logical]
+	return model, ok
+}
+func (p *openRouterProvider) NextKey() string     { return p.keys.Next() }
+func (p *openRouterProvider) KeyCount() int       { return p.keys.Len() }
+func (p *openRouterProvider) AuthHeader(key string) string {
+	if key == "" {
+		return ""
+	}
+	return "Bearer " + key
+}
+
+// openAIProvider обращается напрямую к OpenAI.
+type openAIProvider struct {
+	keys   *KeyRotator
+	models map[string]string
+}
+
+// NewOpenAI создаёт провайдера OpenAI, читая ключи из OPENAI_API_KEY.
+func NewOpenAI() Provider {
+	return &openAIProvider{
+		keys: NewKeyRotator(ParseKeys(os.Getenv("OPENAI_API_KEY"))),
+		models: map[string]string{
+			"gpt-4o-mini": "gpt-4o-mini",
+		},
+	}
+}
+
+func (p *openAIProvider) Name() string     { return "openai" }
+func (p *openAIProvider) Endpoint() string { return "https://api.openai.com/v1/chat/completions" }
+func (p *openAIProvider) ResolveModel(logical string) (string, bool) {
+	model, ok := p.models[logical]
+	return model, ok
+}
+func (p *openAIProvider) NextKey() string     { return p.keys.Next() }
+func (p *openAIProvider) KeyCount() int       { return p.keys.Len() }
+func (p *openAIProvider) AuthHeader(key string) string {
+	if key == "" {
+		return ""
+	}
+	return "Bearer " + key
+}
+
+// deepSeekProvider обращается к DeepSeek напрямую, в обход OpenRouter.
+type deepSeekProvider struct {
+	keys   *KeyRotator
+	models map[string]string
+}
+
+// NewDeepSeek создаёт провайдера DeepSeek, читая ключи из DEEPSEEK_API_KEY.
+func NewDeepSeek() Provider {
+	return &deepSeekProvider{
+		keys: NewKeyRotator(ParseKeys(os.Getenv("DEEPSEEK_API_KEY"))),
+		models: map[string]string{
+			"deepseek-chat": "deepseek-chat",
+		},
+	}
+}
+
+func (p *deepSeekProvider) Name() string     { return "deepseek" }
+func (p *deepSeekProvider) Endpoint() string { return "https://api.deepseek.com/chat/completions" }
+func (p *deepSeekProvider) ResolveModel(logical string) (string, bool) {
+	model, ok := p.models[logical]
+	return model, ok
+}
+func (p *deepSeekProvider) NextKey() string     { return p.keys.Next() }
+func (p *deepSeekProvider) KeyCount() int       { return p.keys.Len() }
+func (p *deepSeekProvider) AuthHeader(key string) string {
+	if key == "" {
+		return ""
+	}
+	return "Bearer " + key
+}
+
+// ollamaProvider обращается к локальному серверу Ollama, который ключей не требует.
+type ollamaProvider struct {
+	endpoint string
+	models   map[string]string
+}
+
+// NewOllama создаёт провайдера для локальной Ollama. Адрес можно
+// переопределить через OLLAMA_HOST (по умолчанию http://localhost:11434).
+func NewOllama() Provider {
+	host := os.Getenv("OLLAMA_HOST")
+	if host == "" {
+		host = "http://localhost:11434"
+	}
+	return &ollamaProvider{
+		endpoint: host + "/v1/chat/completions",
+		models: map[string]string{
+			"deepseek-chat": "deepseek-r1",
+		},
+	}
+}
+
+func (p *ollamaProvider) Name() string     { return "ollama" }
+func (p *ollamaProvider) Endpoint() string { return p.endpoint }
+func (p *ollamaProvider) ResolveModel(logical string) (string, bool) {
+	model, ok := p.models[logical]
+	return model, ok
+}
+func (p *ollamaProvider) NextKey() string             { return "" }
+func (p *ollamaProvider) KeyCount() int               { return 0 }
+func (p *ollamaProvider) AuthHeader(key string) string { return "" }
+
+// DefaultRegistry собирает реестр из всех известных провайдеров в порядке
+// предпочтения: облачные сначала, локальная Ollama — как последний резерв.
+func DefaultRegistry() *Registry {
+	return NewRegistry(NewOpenRouter(), NewOpenAI(), NewDeepSeek(), NewOllama())
+}
@@ -0,0 +1,215 @@
+// Package prompts загружает шаблоны промптов из каталога на диске,
+// организованного как <locale>/<style>.tmpl, и выполняет их с учётом
+// темы и длины факта. Шаблоны перечитываются с диска при изменении, а
+// сам каталог периодически пересканируется на новые файлы — всё это
+// удобно при разработке, не нужно перезапускать сервер.
+package prompts
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// rescanInterval — минимальный промежуток между повторными сканированиями
+// каталога шаблонов на появление новых файлов <locale>/<style>.tmpl.
+const rescanInterval = 2 * time.Second
+
+// Key — идентификатор шаблона: язык и стиль изложения.
+type Key struct {
+	Locale string
+	Style  string
+}
+
+// Data — значения, подставляемые в шаблон.
+type Data struct {
+	Topic  string
+	Length string
+}
+
+type entry struct {
+	path    string
+	tmpl    *template.Template
+	modTime int64
+}
+
+// Manager хранит разобранные шаблоны и перечитывает их с диска при изменении.
+type Manager struct {
+	dir string
+
+	mu       sync.RWMutex
+	entries  map[Key]*entry
+	lastScan time.Time
+}
+
+// NewManager сканирует dir на файлы вида <locale>/<style>.tmpl и строит
+// реестр доступных комбинаций. Сами шаблоны разбираются лениво при первом
+// обращении в Render.
+func NewManager(dir string) (*Manager, error) {
+	m := &Manager{dir: dir, entries: make(map[Key]*entry)}
+	if err := m.scan(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Manager) scan() error {
+	found := make(map[Key]string)
+
+	err := filepath.WalkDir(m.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".tmpl" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(m.dir, path)
+		if err != nil {
+			return err
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if len(parts) != 2 {
+			return nil // ожидаем <locale>/<style>.tmpl
+		}
+
+		key := Key{Locale: parts[0], Style: strings.TrimSuffix(parts[1], ".tmpl")}
+		found[key] = path
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("prompts: сканирование %s: %w", m.dir, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := make(map[Key]*entry, len(found))
+	for key, path := range found {
+		if e, ok := m.entries[key]; ok && e.path == path {
+			entries[key] = e // уже разобран — сохраняем кэш
+			continue
+		}
+		entries[key] = &entry{path: path}
+	}
+	m.entries = entries
+	m.lastScan = time.Now()
+	return nil
+}
+
+// rescanIfStale пересканирует каталог, если с прошлого раза прошло больше
+// rescanInterval, — так появившиеся на диске новые <locale>/<style>.tmpl
+// подхватываются без перезапуска сервера. Ошибки сканирования игнорируются:
+// пока каталог недоступен, Manager продолжает работать с тем, что уже знает.
+func (m *Manager) rescanIfStale() {
+	m.mu.RLock()
+	stale := time.Since(m.lastScan) > rescanInterval
+	m.mu.RUnlock()
+	if !stale {
+		return
+	}
+	_ = m.scan()
+}
+
+// Keys возвращает список доступных комбинаций (locale, style) в
+// стабильном порядке, для выдачи в GET /api/prompts.
+func (m *Manager) Keys() []Key {
+	m.rescanIfStale()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]Key, 0, len(m.entries))
+	for k := range m.entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Locale != keys[j].Locale {
+			return keys[i].Locale < keys[j].Locale
+		}
+		return keys[i].Style < keys[j].Style
+	})
+	return keys
+}
+
+// Resolve подбирает ближайшую доступную комбинацию: запрошенный
+// (locale, style), затем (locale, "default"), затем ("ru", "default").
+func (m *Manager) Resolve(locale, style string) Key {
+	m.rescanIfStale()
+
+	if locale == "" {
+		locale = "ru"
+	}
+	if style == "" {
+		style = "default"
+	}
+
+	if m.has(Key{locale, style}) {
+		return Key{locale, style}
+	}
+	if m.has(Key{locale, "default"}) {
+		return Key{locale, "default"}
+	}
+	return Key{"ru", "default"}
+}
+
+func (m *Manager) has(k Key) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.entries[k]
+	return ok
+}
+
+// Render выполняет шаблон key с данными data, перечитывая его с диска,
+// если файл изменился с прошлого обращения.
+func (m *Manager) Render(key Key, data Data) (string, error) {
+	m.mu.RLock()
+	e, ok := m.entries[key]
+	m.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("prompts: шаблон %s/%s не найден", key.Locale, key.Style)
+	}
+
+	tmpl, err := m.loaded(key, e)
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("prompts: выполнение шаблона %s/%s: %w", key.Locale, key.Style, err)
+	}
+	return buf.String(), nil
+}
+
+func (m *Manager) loaded(key Key, e *entry) (*template.Template, error) {
+	info, err := os.Stat(e.path)
+	if err != nil {
+		return nil, fmt.Errorf("prompts: чтение %s: %w", e.path, err)
+	}
+
+	m.mu.RLock()
+	fresh := e.tmpl != nil && e.modTime == info.ModTime().UnixNano()
+	tmpl := e.tmpl
+	m.mu.RUnlock()
+	if fresh {
+		return tmpl, nil
+	}
+
+	tmpl, err = template.ParseFiles(e.path)
+	if err != nil {
+		return nil, fmt.Errorf("prompts: разбор %s: %w", e.path, err)
+	}
+
+	m.mu.Lock()
+	e.tmpl = tmpl
+	e.modTime = info.ModTime().UnixNano()
+	m.mu.Unlock()
+	return tmpl, nil
+}
@@ -0,0 +1,69 @@
+// Package ratelimit реализует token-bucket ограничение частоты запросов.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store хранит состояние token bucket для каждого ключа (обычно IP-адреса).
+// MemoryStore подходит для одного инстанса сервера; для нескольких
+// инстансов можно подставить реализацию поверх Redis, реализующую тот же
+// интерфейс, чтобы бакеты были общими.
+type Store interface {
+	// Allow пытается списать один токен с бакета key. rps — скорость
+	// пополнения бакета в токенах в секунду, burst — его ёмкость.
+	// Если токенов не хватило, возвращает false и время до появления
+	// следующего токена.
+	Allow(ctx context.Context, key string, rps float64, burst int) (allowed bool, retryAfter time.Duration)
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// MemoryStore — хранилище бакетов в памяти процесса, безопасное для
+// конкурентного использования.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewMemoryStore создаёт пустое хранилище бакетов в памяти.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+// Allow реализует Store.
+func (s *MemoryStore) Allow(_ context.Context, key string, rps float64, burst int) (bool, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(burst), updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	b.tokens = minFloat(float64(burst), b.tokens+elapsed*rps)
+	b.updatedAt = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	missing := 1 - b.tokens
+	return false, time.Duration(missing / rps * float64(time.Second))
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// APIKey — запись о ключе доступа и его дневной квоте запросов.
+type APIKey struct {
+	Key        string
+	DailyQuota int
+}
+
+// ErrKeyNotFound возвращается, когда переданный API-ключ не зарегистрирован.
+var ErrKeyNotFound = errors.New("storage: ключ не найден")
+
+func (s *Store) migrateKeys() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS api_keys (
+			key         TEXT PRIMARY KEY,
+			daily_quota INTEGER NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS api_key_usage (
+			key   TEXT NOT NULL,
+			day   TEXT NOT NULL,
+			count INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (key, day)
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("storage: миграция схемы ключей: %w", err)
+	}
+	return nil
+}
+
+// GetAPIKey возвращает дневную квоту ключа, либо ErrKeyNotFound, если он не зарегистрирован.
+func (s *Store) GetAPIKey(ctx context.Context, key string) (*APIKey, error) {
+	var k APIKey
+	err := s.db.QueryRowContext(ctx, `SELECT key, daily_quota FROM api_keys WHERE key = ?`, key).
+		Scan(&k.Key, &k.DailyQuota)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("storage: выборка ключа: %w", err)
+	}
+	return &k, nil
+}
+
+// PutAPIKey регистрирует ключ с заданной дневной квотой (или обновляет её).
+func (s *Store) PutAPIKey(ctx context.Context, key string, dailyQuota int) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO api_keys (key, daily_quota) VALUES (?, ?)
+		 ON CONFLICT(key) DO UPDATE SET daily_quota = excluded.daily_quota`,
+		key, dailyQuota,
+	)
+	if err != nil {
+		return fmt.Errorf("storage: сохранение ключа: %w", err)
+	}
+	return nil
+}
+
+// QuotaUsed возвращает текущее значение счётчика использования ключа за
+// сегодняшний день (0, если ключ сегодня ещё не использовался).
+func (s *Store) QuotaUsed(ctx context.Context, key string) (int, error) {
+	day := time.Now().UTC().Format("2006-01-02")
+
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT count FROM api_key_usage WHERE key = ? AND day = ?`, key, day,
+	).Scan(&count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("storage: чтение квоты: %w", err)
+	}
+	return count, nil
+}
+
+// ConsumeQuota увеличивает счётчик использования ключа за сегодняшний день
+// и возвращает значение счётчика после инкремента. Вызывающий код должен
+// сначала проверить QuotaUsed — ConsumeQuota сам квоту не ограничивает.
+func (s *Store) ConsumeQuota(ctx context.Context, key string) (int, error) {
+	day := time.Now().UTC().Format("2006-01-02")
+
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO api_key_usage (key, day, count) VALUES (?, ?, 1)
+		 ON CONFLICT(key, day) DO UPDATE SET count = count + 1`,
+		key, day,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("storage: обновление квоты: %w", err)
+	}
+
+	var count int
+	err = s.db.QueryRowContext(ctx,
+		`SELECT count FROM api_key_usage WHERE key = ? AND day = ?`, key, day,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("storage: чтение квоты: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,197 @@
+// Package storage хранит сгенерированные факты в SQLite (через
+// modernc.org/sqlite, без cgo) вместе с их темой, моделью, временем
+// создания и оценкой пользователя.
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// Fact — один сгенерированный факт вместе с метаданными.
+type Fact struct {
+	ID        int64
+	Topic     string
+	Model     string
+	Content   string
+	Rating    *int
+	CreatedAt time.Time
+}
+
+// TopicCount — агрегат для топ-тем.
+type TopicCount struct {
+	Topic string
+	Count int
+}
+
+// Store — обёртка над *sql.DB с методами, специфичными для фактов.
+type Store struct {
+	db *sql.DB
+}
+
+// Open открывает (и при необходимости создаёт) SQLite-базу по указанному пути
+// и прогоняет миграцию схемы.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("storage: открытие базы: %w", err)
+	}
+
+	s := &Store{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := s.migrateKeys(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Store) migrate() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS facts (
+			id         INTEGER PRIMARY KEY AUTOINCREMENT,
+			topic      TEXT NOT NULL DEFAULT '',
+			model      TEXT NOT NULL DEFAULT '',
+			content    TEXT NOT NULL,
+			rating     INTEGER,
+			created_at DATETIME NOT NULL
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("storage: миграция схемы: %w", err)
+	}
+	return nil
+}
+
+// Close закрывает соединение с базой.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// InsertFact сохраняет сгенерированный факт и возвращает его ID.
+func (s *Store) InsertFact(ctx context.Context, topic, model, content string) (int64, error) {
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO facts (topic, model, content, created_at) VALUES (?, ?, ?, ?)`,
+		topic, model, content, time.Now().UTC(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("storage: сохранение факта: %w", err)
+	}
+	return res.LastInsertId()
+}
+
+// ListFacts возвращает факты, отсортированные от новых к старым, с
+// постраничной выборкой и опциональной фильтрацией по теме.
+func (s *Store) ListFacts(ctx context.Context, topic string, limit, offset int) ([]Fact, error) {
+	query := `SELECT id, topic, model, content, rating, created_at FROM facts`
+	args := []interface{}{}
+	if topic != "" {
+		query += ` WHERE topic = ?`
+		args = append(args, topic)
+	}
+	query += ` ORDER BY id DESC LIMIT ? OFFSET ?`
+	args = append(args, limit, offset)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("storage: выборка фактов: %w", err)
+	}
+	defer rows.Close()
+
+	var facts []Fact
+	for rows.Next() {
+		f, err := scanFact(rows)
+		if err != nil {
+			return nil, err
+		}
+		facts = append(facts, f)
+	}
+	return facts, rows.Err()
+}
+
+// GetFact возвращает факт по ID, либо sql.ErrNoRows, если он не найден.
+func (s *Store) GetFact(ctx context.Context, id int64) (*Fact, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT id, topic, model, content, rating, created_at FROM facts WHERE id = ?`, id,
+	)
+	f, err := scanFact(row)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// RateFact проставляет оценку пользователя для факта.
+func (s *Store) RateFact(ctx context.Context, id int64, rating int) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE facts SET rating = ? WHERE id = ?`, rating, id)
+	if err != nil {
+		return fmt.Errorf("storage: сохранение оценки: %w", err)
+	}
+	return checkRowAffected(res)
+}
+
+// DeleteFact удаляет факт по ID.
+func (s *Store) DeleteFact(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM facts WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("storage: удаление факта: %w", err)
+	}
+	return checkRowAffected(res)
+}
+
+// TopTopics возвращает самые часто запрашиваемые темы.
+func (s *Store) TopTopics(ctx context.Context, limit int) ([]TopicCount, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT topic, COUNT(*) AS cnt FROM facts WHERE topic != '' GROUP BY topic ORDER BY cnt DESC LIMIT ?`, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("storage: выборка тем: %w", err)
+	}
+	defer rows.Close()
+
+	var topics []TopicCount
+	for rows.Next() {
+		var tc TopicCount
+		if err := rows.Scan(&tc.Topic, &tc.Count); err != nil {
+			return nil, err
+		}
+		topics = append(topics, tc)
+	}
+	return topics, rows.Err()
+}
+
+// rowScanner объединяет *sql.Row и *sql.Rows, чтобы scanFact работал с обоими.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanFact(row rowScanner) (Fact, error) {
+	var f Fact
+	var rating sql.NullInt64
+	if err := row.Scan(&f.ID, &f.Topic, &f.Model, &f.Content, &rating, &f.CreatedAt); err != nil {
+		return Fact{}, err
+	}
+	if rating.Valid {
+		v := int(rating.Int64)
+		f.Rating = &v
+	}
+	return f, nil
+}
+
+func checkRowAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
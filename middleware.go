@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"fact-generator/internal/ratelimit"
+	"fact-generator/internal/storage"
+)
+
+const (
+	defaultRPS   = 1.0
+	defaultBurst = 5
+)
+
+// limiter ограничивает частоту запросов по IP. По умолчанию хранит бакеты в
+// памяти процесса; для нескольких инстансов сервера можно подставить
+// реализацию ratelimit.Store поверх Redis с общим состоянием.
+var limiter ratelimit.Store = ratelimit.NewMemoryStore()
+
+// rateLimitMiddleware ограничивает /generate-fact по IP-адресу клиента, а
+// при наличии заголовка X-API-Key — ещё и по дневной квоте ключа.
+func rateLimitMiddleware(next http.Handler) http.Handler {
+	rps := envFloat("RATE_LIMIT_RPS", defaultRPS)
+	burst := envInt("RATE_LIMIT_BURST", defaultBurst)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if apiKey := r.Header.Get("X-API-Key"); apiKey != "" {
+			remaining, retryAfter, err := checkAPIKeyQuota(r, apiKey)
+			if err != nil {
+				if errors.Is(err, storage.ErrKeyNotFound) {
+					http.Error(w, "Неизвестный API-ключ", http.StatusUnauthorized)
+					return
+				}
+				http.Error(w, "Ошибка проверки квоты: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if retryAfter > 0 {
+				writeRateLimitError(w, retryAfter, remaining)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, retryAfter := limiter.Allow(r.Context(), clientIP(r), rps, burst)
+		if !allowed {
+			writeRateLimitError(w, retryAfter, 0)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// checkAPIKeyQuota сравнивает текущее использование ключа с дневной квотой и,
+// если она ещё не исчерпана, увеличивает счётчик. retryAfter > 0 означает,
+// что квота исчерпана; в этом случае счётчик не растёт, иначе клиент,
+// продолжающий слать запросы сверх квоты, мог бы разгонять его бесконечно.
+func checkAPIKeyQuota(r *http.Request, key string) (remaining int, retryAfter time.Duration, err error) {
+	apiKey, err := store.GetAPIKey(r.Context(), key)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	used, err := store.QuotaUsed(r.Context(), key)
+	if err != nil {
+		return 0, 0, err
+	}
+	if used >= apiKey.DailyQuota {
+		return 0, time.Until(endOfUTCDay()), nil
+	}
+
+	used, err = store.ConsumeQuota(r.Context(), key)
+	if err != nil {
+		return 0, 0, err
+	}
+	return apiKey.DailyQuota - used, 0, nil
+}
+
+func endOfUTCDay() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeRateLimitError(w http.ResponseWriter, retryAfter time.Duration, remaining int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error":       "превышен лимит запросов",
+		"retry_after": int(retryAfter.Seconds()),
+		"remaining":   remaining,
+	})
+}
+
+func envFloat(name string, def float64) float64 {
+	if v, err := strconv.ParseFloat(os.Getenv(name), 64); err == nil && v > 0 {
+		return v
+	}
+	return def
+}
+
+func envInt(name string, def int) int {
+	if v, err := strconv.Atoi(os.Getenv(name)); err == nil && v > 0 {
+		return v
+	}
+	return def
+}